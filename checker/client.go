@@ -0,0 +1,78 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RepoClient is the forge-neutral surface that checks run against, so the same check logic can
+// score a GitHub, Gitea, or (eventually) GitLab repo without binding to a vendor-specific SDK.
+type RepoClient interface {
+	// ListMergedPRs returns merged pull/merge requests, most recent first, paginating until
+	// opts.MaxItems or opts.LookbackDays is reached.
+	ListMergedPRs(ctx context.Context, opts ListOptions) ([]PullRequest, error)
+	// ListReviews returns the reviews left on the given pull/merge request.
+	ListReviews(ctx context.Context, prNumber int) ([]Review, error)
+	// GetBranchProtection returns the protection rules enforced on the repo's default branch.
+	GetBranchProtection(ctx context.Context) (*BranchProtection, error)
+	// ListCommits returns recent commits on the repo's default branch, most recent first,
+	// paginating until opts.MaxItems or opts.LookbackDays is reached.
+	ListCommits(ctx context.Context, opts ListOptions) ([]Commit, error)
+	// GetCommit returns a single commit by SHA.
+	GetCommit(ctx context.Context, sha string) (*Commit, error)
+	// ListNotes returns the raw note blobs attached under the given git notes ref, keyed by the
+	// commit SHA they annotate. Backends that have no notion of git notes return
+	// ErrNotesUnsupported so callers can degrade gracefully instead of panicking; a missing or
+	// unreadable ref on a backend that does support notes is returned as an ordinary error.
+	ListNotes(ctx context.Context, ref string) (map[string][][]byte, error)
+}
+
+// ErrNotesUnsupported is returned by ListNotes on backends (or hosts) that don't expose git
+// notes, so a check built on notes can skip itself instead of failing outright.
+var ErrNotesUnsupported = errors.New("git notes are not supported by this repo client")
+
+// PullRequest is a forge-neutral view of a merged pull/merge request.
+type PullRequest struct {
+	Number         int
+	Body           string
+	MergedAt       time.Time
+	MergeCommitSHA string
+	Labels         []string
+}
+
+// Review is a forge-neutral view of a single review left on a pull/merge request.
+type Review struct {
+	State string
+}
+
+// Commit is a forge-neutral view of a single commit.
+type Commit struct {
+	SHA            string
+	Message        string
+	AuthorLogin    string
+	CommitterLogin string
+	CommittedDate  time.Time
+}
+
+// BranchProtection is a forge-neutral view of the protection rules enforced on a branch.
+type BranchProtection struct {
+	RequiredApprovingReviewCount   int
+	RequireCodeOwnerReviews        bool
+	RequiredConversationResolution bool
+	RequiredStatusCheckContexts    []string
+}
@@ -0,0 +1,174 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+// giteaRepoClient implements RepoClient on top of the Gitea SDK. A GitLab-backed client is a
+// natural follow-on once a second self-hosted forge is needed; keep this implementation's shape
+// close to githubRepoClient so that one can be copied rather than designed from scratch.
+type giteaRepoClient struct {
+	client *gitea.Client
+	owner  string
+	repo   string
+}
+
+// NewGiteaRepoClient returns a RepoClient backed by the given Gitea SDK client.
+func NewGiteaRepoClient(client *gitea.Client, owner, repo string) RepoClient {
+	return &giteaRepoClient{client: client, owner: owner, repo: repo}
+}
+
+func (g *giteaRepoClient) ListMergedPRs(ctx context.Context, opts ListOptions) ([]PullRequest, error) {
+	cutoff := opts.Cutoff()
+
+	var prs []PullRequest
+	for page := 1; ; page++ {
+		giteaPRs, _, err := g.client.ListRepoPullRequests(g.owner, g.repo, gitea.ListPullRequestsOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+			State:       gitea.StateClosed,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(giteaPRs) == 0 {
+			break
+		}
+
+		for _, pr := range giteaPRs {
+			if pr.Merged == nil {
+				continue
+			}
+			if pr.Merged.Before(cutoff) {
+				return prs, nil
+			}
+			var labels []string
+			for _, l := range pr.Labels {
+				labels = append(labels, l.Name)
+			}
+			prs = append(prs, PullRequest{
+				Number:         int(pr.Index),
+				Body:           pr.Body,
+				MergedAt:       *pr.Merged,
+				MergeCommitSHA: pr.MergedCommitID,
+				Labels:         labels,
+			})
+			if len(prs) >= opts.MaxItems {
+				return prs, nil
+			}
+		}
+	}
+	return prs, nil
+}
+
+func (g *giteaRepoClient) ListReviews(ctx context.Context, prNumber int) ([]Review, error) {
+	giteaReviews, _, err := g.client.ListPullReviews(g.owner, g.repo, int64(prNumber), gitea.ListPullReviewsOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []Review
+	for _, r := range giteaReviews {
+		reviews = append(reviews, Review{State: string(r.State)})
+	}
+	return reviews, nil
+}
+
+func (g *giteaRepoClient) GetBranchProtection(ctx context.Context) (*BranchProtection, error) {
+	repo, _, err := g.client.GetRepo(g.owner, g.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	bp, _, err := g.client.GetBranchProtection(g.owner, g.repo, repo.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BranchProtection{
+		RequiredApprovingReviewCount: bp.RequiredApprovals,
+		// EnableApprovalsWhitelist only restricts which users/teams count as approvers; it says
+		// nothing about CODEOWNERS. BlockOnOfficialReviewRequests is what Gitea's CODEOWNERS
+		// integration actually flips on: it blocks merging until the officially-requested
+		// reviewers (the ones a matching CODEOWNERS entry auto-requests) have approved.
+		RequireCodeOwnerReviews:     bp.BlockOnOfficialReviewRequests,
+		RequiredStatusCheckContexts: bp.StatusCheckContexts,
+	}, nil
+}
+
+func (g *giteaRepoClient) ListCommits(ctx context.Context, opts ListOptions) ([]Commit, error) {
+	cutoff := opts.Cutoff()
+
+	var commits []Commit
+	for page := 1; ; page++ {
+		giteaCommits, _, err := g.client.ListRepoCommits(g.owner, g.repo, gitea.ListCommitOptions{
+			ListOptions: gitea.ListOptions{Page: page, PageSize: 50},
+			SHA:         "",
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(giteaCommits) == 0 {
+			break
+		}
+
+		for _, c := range giteaCommits {
+			commit := toGiteaCommit(c)
+			if !commit.CommittedDate.IsZero() && commit.CommittedDate.Before(cutoff) {
+				return commits, nil
+			}
+			commits = append(commits, commit)
+			if len(commits) >= opts.MaxItems {
+				return commits, nil
+			}
+		}
+	}
+	return commits, nil
+}
+
+// ListNotes reports ErrNotesUnsupported: Gitea has no git-notes API endpoint, so checks built on
+// it (e.g. GitAppraiseCodeReview) should skip themselves on a Gitea target rather than fail.
+func (g *giteaRepoClient) ListNotes(ctx context.Context, ref string) (map[string][][]byte, error) {
+	return nil, ErrNotesUnsupported
+}
+
+func (g *giteaRepoClient) GetCommit(ctx context.Context, sha string) (*Commit, error) {
+	giteaCommit, _, err := g.client.GetSingleCommit(g.owner, g.repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	c := toGiteaCommit(giteaCommit)
+	return &c, nil
+}
+
+func toGiteaCommit(giteaCommit *gitea.Commit) Commit {
+	c := Commit{
+		SHA:     giteaCommit.SHA,
+		Message: giteaCommit.RepoCommit.Message,
+	}
+	if giteaCommit.Author != nil {
+		c.AuthorLogin = giteaCommit.Author.UserName
+	}
+	if giteaCommit.Committer != nil {
+		c.CommitterLogin = giteaCommit.Committer.UserName
+	}
+	if giteaCommit.RepoCommit.Committer != nil {
+		c.CommittedDate = giteaCommit.RepoCommit.Committer.Date
+	}
+	return c
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.gitea.io/sdk/gitea"
+)
+
+func TestGiteaListNotesUnsupported(t *testing.T) {
+	g := &giteaRepoClient{}
+	_, err := g.ListNotes(context.Background(), "refs/notes/devtools/reviews")
+	if err != ErrNotesUnsupported {
+		t.Errorf("ListNotes() error = %v, want ErrNotesUnsupported", err)
+	}
+}
+
+func TestToGiteaCommit(t *testing.T) {
+	committedDate := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	giteaCommit := &gitea.Commit{
+		SHA: "abc123",
+		RepoCommit: &gitea.RepoCommit{
+			Message: "fix the thing\n",
+			Committer: &gitea.RepoUserInfo{
+				Date: committedDate,
+			},
+		},
+		Author:    &gitea.User{UserName: "alice"},
+		Committer: &gitea.User{UserName: "bob"},
+	}
+
+	c := toGiteaCommit(giteaCommit)
+	if c.SHA != "abc123" {
+		t.Errorf("SHA = %q, want %q", c.SHA, "abc123")
+	}
+	if c.Message != "fix the thing\n" {
+		t.Errorf("Message = %q, want %q", c.Message, "fix the thing\n")
+	}
+	if c.AuthorLogin != "alice" {
+		t.Errorf("AuthorLogin = %q, want %q", c.AuthorLogin, "alice")
+	}
+	if c.CommitterLogin != "bob" {
+		t.Errorf("CommitterLogin = %q, want %q", c.CommitterLogin, "bob")
+	}
+	if !c.CommittedDate.Equal(committedDate) {
+		t.Errorf("CommittedDate = %v, want %v", c.CommittedDate, committedDate)
+	}
+}
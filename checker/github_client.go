@@ -0,0 +1,266 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+)
+
+// githubRepoClient implements RepoClient on top of the go-github REST client.
+type githubRepoClient struct {
+	client *github.Client
+	owner  string
+	repo   string
+}
+
+// NewGithubRepoClient returns a RepoClient backed by the given go-github client.
+func NewGithubRepoClient(client *github.Client, owner, repo string) RepoClient {
+	return &githubRepoClient{client: client, owner: owner, repo: repo}
+}
+
+// maxStalePRPages bounds how many consecutive pages with no in-window merged PR ListMergedPRs
+// will tolerate before giving up. The list is sorted by update time, not merge time, so a PR
+// merged inside the window can still be interleaved with older PRs that were merely commented on
+// or labeled more recently -- one stale page isn't proof the whole window has been scanned.
+const maxStalePRPages = 3
+
+func (g *githubRepoClient) ListMergedPRs(ctx context.Context, opts ListOptions) ([]PullRequest, error) {
+	cutoff := opts.Cutoff()
+	listOpts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var prs []PullRequest
+	stalePages := 0
+	for {
+		if err := g.awaitRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		ghPRs, resp, err := g.client.PullRequests.List(ctx, g.owner, g.repo, listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		pageInWindow := false
+		for _, pr := range ghPRs {
+			if pr.MergedAt == nil || pr.MergedAt.Before(cutoff) {
+				continue
+			}
+			pageInWindow = true
+			var labels []string
+			for _, l := range pr.Labels {
+				labels = append(labels, l.GetName())
+			}
+			prs = append(prs, PullRequest{
+				Number:         pr.GetNumber(),
+				Body:           pr.GetBody(),
+				MergedAt:       *pr.MergedAt,
+				MergeCommitSHA: pr.GetMergeCommitSHA(),
+				Labels:         labels,
+			})
+			if len(prs) >= opts.MaxItems {
+				return prs, nil
+			}
+		}
+
+		if pageInWindow {
+			stalePages = 0
+		} else {
+			stalePages++
+			if stalePages >= maxStalePRPages {
+				return prs, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+	return prs, nil
+}
+
+// awaitRateLimit queries GitHub's rate-limit endpoint and, if it reports the remaining core quota
+// as exhausted, sleeps until the reset time, so a long paginated scan doesn't start erroring
+// partway through.
+func (g *githubRepoClient) awaitRateLimit(ctx context.Context) error {
+	rate, _, err := g.client.RateLimit(ctx)
+	if err != nil {
+		// Rate-limit status isn't critical; fall through and let the real call surface errors.
+		return nil
+	}
+	if rate.Core.Remaining > 0 {
+		return nil
+	}
+
+	wait := time.Until(rate.Core.Reset.Time)
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+func (g *githubRepoClient) ListReviews(ctx context.Context, prNumber int) ([]Review, error) {
+	if err := g.awaitRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	ghReviews, _, err := g.client.PullRequests.ListReviews(ctx, g.owner, g.repo, prNumber, &github.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var reviews []Review
+	for _, r := range ghReviews {
+		reviews = append(reviews, Review{State: r.GetState()})
+	}
+	return reviews, nil
+}
+
+func (g *githubRepoClient) GetBranchProtection(ctx context.Context) (*BranchProtection, error) {
+	r, _, err := g.client.Repositories.Get(ctx, g.owner, g.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	bp, _, err := g.client.Repositories.GetBranchProtection(ctx, g.owner, g.repo, r.GetDefaultBranch())
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts []string
+	if rsc := bp.GetRequiredStatusChecks(); rsc != nil {
+		contexts = rsc.Contexts
+	}
+	result := &BranchProtection{
+		RequiredStatusCheckContexts: contexts,
+	}
+	if rprr := bp.GetRequiredPullRequestReviews(); rprr != nil {
+		result.RequiredApprovingReviewCount = rprr.RequiredApprovingReviewCount
+		result.RequireCodeOwnerReviews = rprr.RequireCodeOwnerReviews
+	}
+	if rcr := bp.GetRequiredConversationResolution(); rcr != nil {
+		result.RequiredConversationResolution = rcr.Enabled
+	}
+	return result, nil
+}
+
+func (g *githubRepoClient) ListCommits(ctx context.Context, opts ListOptions) ([]Commit, error) {
+	cutoff := opts.Cutoff()
+	listOpts := &github.CommitsListOptions{
+		Since:       cutoff,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var commits []Commit
+	for {
+		if err := g.awaitRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		ghCommits, resp, err := g.client.Repositories.ListCommits(ctx, g.owner, g.repo, listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range ghCommits {
+			commits = append(commits, toCommit(c))
+			if len(commits) >= opts.MaxItems {
+				return commits, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+	return commits, nil
+}
+
+func (g *githubRepoClient) GetCommit(ctx context.Context, sha string) (*Commit, error) {
+	if err := g.awaitRateLimit(ctx); err != nil {
+		return nil, err
+	}
+
+	ghCommit, _, err := g.client.Repositories.GetCommit(ctx, g.owner, g.repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	c := toCommit(ghCommit)
+	return &c, nil
+}
+
+// ListNotes fetches the notes tree for ref and returns, for each commit SHA that has a note, the
+// raw blobs attached to it. Notes ref layouts fan out by commit SHA across one or more tree
+// levels (e.g. "<sha>" or "<sha2>/<sha38>"), so we join path segments back into a single SHA.
+func (g *githubRepoClient) ListNotes(ctx context.Context, ref string) (map[string][][]byte, error) {
+	r, _, err := g.client.Git.GetRef(ctx, g.owner, g.repo, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, _, err := g.client.Git.GetTree(ctx, g.owner, g.repo, r.GetObject().GetSHA(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := map[string][][]byte{}
+	for _, entry := range tree.Entries {
+		if entry.GetType() != "blob" {
+			continue
+		}
+		sha := strings.ReplaceAll(entry.GetPath(), "/", "")
+		blob, _, err := g.client.Git.GetBlob(ctx, g.owner, g.repo, entry.GetSHA())
+		if err != nil {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(blob.GetContent())
+		if err != nil {
+			continue
+		}
+		notes[sha] = append(notes[sha], content)
+	}
+	return notes, nil
+}
+
+func toCommit(ghCommit *github.RepositoryCommit) Commit {
+	var committedDate time.Time
+	if d := ghCommit.GetCommit().GetCommitter().GetDate(); !d.IsZero() {
+		committedDate = d
+	}
+	return Commit{
+		SHA:            ghCommit.GetSHA(),
+		Message:        ghCommit.GetCommit().GetMessage(),
+		AuthorLogin:    ghCommit.GetAuthor().GetLogin(),
+		CommitterLogin: ghCommit.GetCommitter().GetLogin(),
+		CommittedDate:  committedDate,
+	}
+}
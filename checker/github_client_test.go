@@ -0,0 +1,146 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v32/github"
+)
+
+// setupGithubTestClient spins up a fake GitHub REST API and returns a githubRepoClient pointed at
+// it, so ListMergedPRs/ListCommits/ListNotes can be exercised without a real network call.
+func setupGithubTestClient(t *testing.T) (*http.ServeMux, *githubRepoClient, func()) {
+	t.Helper()
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	client := github.NewClient(nil)
+	u, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("parsing test server url: %v", err)
+	}
+	client.BaseURL = u
+	client.UploadURL = u
+
+	return mux, &githubRepoClient{client: client, owner: "owner", repo: "repo"}, server.Close
+}
+
+func TestGithubListMergedPRsToleratesAStalePage(t *testing.T) {
+	mux, c, teardown := setupGithubTestClient(t)
+	defer teardown()
+
+	now := time.Now()
+	inWindow := now.AddDate(0, 0, -1)
+	beforeCutoff := now.AddDate(0, 0, -365)
+
+	mux.HandleFunc("/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			w.Header().Set("Link", `<https://api.github.com/repos/owner/repo/pulls?page=2>; rel="next"`)
+			fmt.Fprintf(w, `[{"number": 1, "merged_at": %q}]`, beforeCutoff.Format(time.RFC3339))
+		case "2":
+			fmt.Fprintf(w, `[{"number": 2, "merged_at": %q}]`, inWindow.Format(time.RFC3339))
+		default:
+			t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+
+	opts := ListOptions{MaxItems: 100, LookbackDays: 90}
+	prs, err := c.ListMergedPRs(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListMergedPRs returned error: %v", err)
+	}
+	if len(prs) != 1 || prs[0].Number != 2 {
+		t.Fatalf("ListMergedPRs = %+v, want the single in-window PR #2", prs)
+	}
+}
+
+func TestGithubListMergedPRsRespectsMaxItems(t *testing.T) {
+	mux, c, teardown := setupGithubTestClient(t)
+	defer teardown()
+
+	now := time.Now()
+	mux.HandleFunc("/repos/owner/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"number": 1, "merged_at": %q}, {"number": 2, "merged_at": %q}]`,
+			now.Format(time.RFC3339), now.Format(time.RFC3339))
+	})
+
+	opts := ListOptions{MaxItems: 1, LookbackDays: 90}
+	prs, err := c.ListMergedPRs(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListMergedPRs returned error: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("ListMergedPRs returned %d PRs, want 1 (MaxItems)", len(prs))
+	}
+}
+
+func TestGithubListCommitsCutoff(t *testing.T) {
+	mux, c, teardown := setupGithubTestClient(t)
+	defer teardown()
+
+	mux.HandleFunc("/repos/owner/repo/commits", func(w http.ResponseWriter, r *http.Request) {
+		if since := r.URL.Query().Get("since"); since == "" {
+			t.Errorf("expected a since= query param, got none")
+		}
+		fmt.Fprint(w, `[{"sha": "abc123", "commit": {"message": "fix it", "committer": {"date": "2020-01-01T00:00:00Z"}}}]`)
+	})
+
+	opts := ListOptions{MaxItems: 100, LookbackDays: 90}
+	commits, err := c.ListCommits(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListCommits returned error: %v", err)
+	}
+	if len(commits) != 1 || commits[0].SHA != "abc123" {
+		t.Fatalf("ListCommits = %+v, want a single commit abc123", commits)
+	}
+}
+
+func TestGithubListNotes(t *testing.T) {
+	mux, c, teardown := setupGithubTestClient(t)
+	defer teardown()
+
+	content := base64.StdEncoding.EncodeToString([]byte(`{"reviewers": ["alice"], "resolved": true}`))
+
+	mux.HandleFunc("/repos/owner/repo/git/ref/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ref": "refs/notes/devtools/reviews", "object": {"sha": "treesha", "type": "commit"}}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/git/trees/treesha", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"sha": "treesha", "entries": [{"path": "ab/cdef0123456789", "type": "blob", "sha": "blobsha"}]}`)
+	})
+	mux.HandleFunc("/repos/owner/repo/git/blobs/blobsha", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"sha": "blobsha", "content": %q, "encoding": "base64"}`, content)
+	})
+
+	notes, err := c.ListNotes(context.Background(), "refs/notes/devtools/reviews")
+	if err != nil {
+		t.Fatalf("ListNotes returned error: %v", err)
+	}
+	sha := "abcdef0123456789"
+	if len(notes[sha]) != 1 {
+		t.Fatalf("ListNotes()[%q] = %v, want a single note blob", sha, notes[sha])
+	}
+	if got := string(notes[sha][0]); got != `{"reviewers": ["alice"], "resolved": true}` {
+		t.Errorf("ListNotes()[%q][0] = %q, want the decoded blob content", sha, got)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/google/go-github/v32/github"
+)
+
+// NewRepoClient parses a `--repo` flag value such as "github.com/owner/repo" or
+// "https://gitea.example.com/owner/repo" and returns the RepoClient backend appropriate for its
+// host, so a single flag picks the right forge without the caller needing to know which one it is.
+func NewRepoClient(repo string, httpClient *http.Client) (RepoClient, error) {
+	parsed, err := ParseRepoURL(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsGithubHost(parsed.Host) {
+		client := github.NewClient(httpClient)
+		if parsed.Host != "github.com" {
+			enterpriseClient, err := github.NewEnterpriseClient(
+				fmt.Sprintf("https://%s/api/v3/", parsed.Host),
+				fmt.Sprintf("https://%s/api/uploads/", parsed.Host),
+				httpClient)
+			if err != nil {
+				return nil, fmt.Errorf("creating github enterprise client for %q: %w", parsed.Host, err)
+			}
+			client = enterpriseClient
+		}
+		return NewGithubRepoClient(client, parsed.Owner, parsed.Repo), nil
+	}
+
+	giteaClient, err := gitea.NewClient(fmt.Sprintf("https://%s", parsed.Host), gitea.SetHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("creating gitea client for %q: %w", parsed.Host, err)
+	}
+	return NewGiteaRepoClient(giteaClient, parsed.Owner, parsed.Repo), nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import "time"
+
+const (
+	defaultMaxItems     = 300
+	defaultLookbackDays = 90
+)
+
+// ListOptions bounds how much history a RepoClient listing call should fetch, so checks score
+// against a meaningful sample of recent activity instead of just whatever fits on the first page.
+type ListOptions struct {
+	// MaxItems caps the number of PRs/commits fetched, across all pages.
+	MaxItems int
+	// LookbackDays excludes items older than this many days and bounds how far back paging goes;
+	// exactly when paging stops is up to each backend.
+	LookbackDays int
+}
+
+// DefaultListOptions returns the ListOptions used when a CheckRequest doesn't override them.
+func DefaultListOptions() ListOptions {
+	return ListOptions{MaxItems: defaultMaxItems, LookbackDays: defaultLookbackDays}
+}
+
+// Cutoff returns the oldest timestamp that should still be included under these options.
+func (o ListOptions) Cutoff() time.Time {
+	return time.Now().AddDate(0, 0, -o.LookbackDays)
+}
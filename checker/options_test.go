@@ -0,0 +1,60 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultListOptions(t *testing.T) {
+	opts := DefaultListOptions()
+	if opts.MaxItems != defaultMaxItems {
+		t.Errorf("MaxItems = %d, want %d", opts.MaxItems, defaultMaxItems)
+	}
+	if opts.LookbackDays != defaultLookbackDays {
+		t.Errorf("LookbackDays = %d, want %d", opts.LookbackDays, defaultLookbackDays)
+	}
+}
+
+func TestListOptionsCutoff(t *testing.T) {
+	tests := []struct {
+		name         string
+		lookbackDays int
+	}{
+		{name: "default lookback", lookbackDays: defaultLookbackDays},
+		{name: "zero lookback", lookbackDays: 0},
+		{name: "single day lookback", lookbackDays: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := ListOptions{LookbackDays: tt.lookbackDays}
+			want := time.Now().AddDate(0, 0, -tt.lookbackDays)
+			got := opts.Cutoff()
+			// got is taken after want, so it may only be later, never earlier.
+			if diff := got.Sub(want); diff < 0 || diff > time.Minute {
+				t.Errorf("Cutoff() = %v, want within a minute of %v", got, want)
+			}
+		})
+	}
+}
+
+func TestListOptionsCutoffOrdering(t *testing.T) {
+	shorter := ListOptions{LookbackDays: 1}.Cutoff()
+	longer := ListOptions{LookbackDays: 30}.Cutoff()
+	if !longer.Before(shorter) {
+		t.Errorf("a longer lookback window should produce an earlier cutoff: got longer=%v, shorter=%v", longer, shorter)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RepoURL is a parsed --repo target: a forge host plus the owner/repo path on it.
+type RepoURL struct {
+	Host  string
+	Owner string
+	Repo  string
+}
+
+// ParseRepoURL splits a `--repo` flag value such as "github.com/owner/repo" or
+// "https://gitea.example.com/owner/repo" into its host and owner/repo components, so callers can
+// pick the right RepoClient backend for the target host.
+func ParseRepoURL(repo string) (*RepoURL, error) {
+	if !strings.Contains(repo, "://") {
+		repo = "https://" + repo
+	}
+
+	u, err := url.Parse(repo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repo url %q: %w", repo, err)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("repo url %q must be in the form host/owner/repo", repo)
+	}
+
+	return &RepoURL{Host: u.Host, Owner: parts[0], Repo: parts[1]}, nil
+}
+
+// IsGithubHost returns true if host is github.com or a subdomain of it. GitHub Enterprise hosts
+// under any other domain are not recognized.
+func IsGithubHost(host string) bool {
+	return host == "github.com" || strings.HasSuffix(host, ".github.com")
+}
@@ -15,10 +15,11 @@
 package checks
 
 import (
+	"encoding/json"
 	"errors"
+	"regexp"
 	"strings"
-
-	"github.com/google/go-github/v32/github"
+	"sync"
 
 	"github.com/ossf/scorecard/checker"
 )
@@ -44,57 +45,168 @@ func DoesCodeReview(c *checker.CheckRequest) checker.CheckResult {
 		IsPrReviewRequired,
 		GithubCodeReview,
 		ProwCodeReview,
+		GitAppraiseCodeReview,
+		PhabricatorCodeReview,
 		CommitMessageHints,
 	)(c)
 }
 
-func GithubCodeReview(c *checker.CheckRequest) checker.CheckResult {
-	// Look at some merged PRs to see if they were reviewed
-	prs, _, err := c.Client.PullRequests.List(c.Ctx, c.Owner, c.Repo, &github.PullRequestListOptions{
-		State: "closed",
-	})
+// gitAppraiseReview models the subset of a git-appraise review note that we care about.
+// See https://github.com/google/git-appraise/blob/master/review/request.go for the full schema.
+type gitAppraiseReview struct {
+	Reviewers []string `json:"reviewers"`
+	Resolved  *bool    `json:"resolved"`
+}
+
+// gitAppraiseAnalysis models a git-appraise CI/analysis report note.
+type gitAppraiseAnalysis struct {
+	Status string `json:"status"`
+}
+
+const (
+	gitAppraiseReviewsRef   = "refs/notes/devtools/reviews"
+	gitAppraiseAnalysesRef  = "refs/notes/devtools/analyses"
+	gitAppraiseAnalysisPass = "pass"
+)
+
+// GitAppraiseCodeReview looks for git-appraise style reviews, which are stored as JSON blobs in
+// git notes attached to the reviewed commit under refs/notes/devtools/reviews (and, for CI
+// results, refs/notes/devtools/analyses) rather than as GitHub pull request reviews.
+func GitAppraiseCodeReview(c *checker.CheckRequest) checker.CheckResult {
+	commits, err := c.RepoClient.ListCommits(c.Ctx, c.ListOptions)
 	if err != nil {
+		return checker.MakeRetryResult(CheckCodeReview, err)
+	}
+
+	// Git notes aren't a universal concept: a backend without them (e.g. Gitea) reports
+	// ErrNotesUnsupported, which means this sub-check simply doesn't apply to this target. A
+	// project may use only the reviews ref, only the analyses ref, or neither, so a missing ref
+	// is tolerated independently for each rather than failing the whole check.
+	reviewNotes, err := gitAppraiseNotes(c, gitAppraiseReviewsRef)
+	if errors.Is(err, checker.ErrNotesUnsupported) {
 		return checker.MakeInconclusiveResult(CheckCodeReview, err)
 	}
+	if err != nil {
+		c.Logf("no git-appraise reviews found under %s: %v", gitAppraiseReviewsRef, err)
+		reviewNotes = nil
+	}
+	analysisNotes, err := gitAppraiseNotes(c, gitAppraiseAnalysesRef)
+	if err != nil {
+		c.Logf("no git-appraise analyses found under %s: %v", gitAppraiseAnalysesRef, err)
+		analysisNotes = nil
+	}
+	if len(reviewNotes) == 0 && len(analysisNotes) == 0 {
+		return checker.MakeInconclusiveResult(CheckCodeReview, ErrorNoReviews)
+	}
 
-	totalMerged := 0
+	total := 0
 	totalReviewed := 0
-	for _, pr := range prs {
-		if pr.MergedAt == nil {
+	for _, commit := range commits {
+		if isBotCommitter(commit.CommitterLogin) {
+			c.Logf("skip commit from bot account: %s", commit.CommitterLogin)
 			continue
 		}
-		totalMerged++
+		total++
 
-		// check if the PR is approved by a reviewer
-		foundApprovedReview := false
-		reviews, _, err := c.Client.PullRequests.ListReviews(c.Ctx, c.Owner, c.Repo, pr.GetNumber(), &github.ListOptions{})
-		if err != nil {
+		sha := commit.SHA
+		if gitAppraiseReviewResolved(reviewNotes[sha]) || gitAppraiseAnalysisPassed(analysisNotes[sha]) {
+			c.Logf("found git-appraise review for commit: %s", sha)
+			totalReviewed++
+		}
+	}
+
+	if totalReviewed == 0 {
+		return checker.MakeInconclusiveResult(CheckCodeReview, ErrorNoReviews)
+	}
+	c.Logf("git-appraise code reviews found")
+	return checker.MakeProportionalResult(CheckCodeReview, totalReviewed, total, .75)
+}
+
+// gitAppraiseNotes fetches the note blobs for ref, keyed by the commit SHA they annotate.
+func gitAppraiseNotes(c *checker.CheckRequest, ref string) (map[string][][]byte, error) {
+	return c.RepoClient.ListNotes(c.Ctx, ref)
+}
+
+func gitAppraiseReviewResolved(rawNotes [][]byte) bool {
+	for _, raw := range rawNotes {
+		var review gitAppraiseReview
+		if err := json.Unmarshal(raw, &review); err != nil {
 			continue
 		}
-		for _, r := range reviews {
-			if r.GetState() == "APPROVED" {
-				c.Logf("found review approved pr: %d", pr.GetNumber())
-				totalReviewed++
-				foundApprovedReview = true
-				break
-			}
+		if len(review.Reviewers) > 0 && review.Resolved != nil && *review.Resolved {
+			return true
+		}
+	}
+	return false
+}
+
+func gitAppraiseAnalysisPassed(rawNotes [][]byte) bool {
+	for _, raw := range rawNotes {
+		var analysis gitAppraiseAnalysis
+		if err := json.Unmarshal(raw, &analysis); err != nil {
+			continue
 		}
+		if strings.EqualFold(analysis.Status, gitAppraiseAnalysisPass) {
+			return true
+		}
+	}
+	return false
+}
 
-		// check if the PR is committed by someone other than author. this is kind
-		// of equivalent to a review and is done several times on small prs to save
-		// time on clicking the approve button.
-		if !foundApprovedReview {
-			commit, _, err := c.Client.Repositories.GetCommit(c.Ctx, c.Owner, c.Repo, pr.GetMergeCommitSHA())
-			if err == nil {
-				commitAuthor := commit.GetAuthor().GetLogin()
-				commitCommitter := commit.GetCommitter().GetLogin()
-				if commitAuthor != "" && commitCommitter != "" && commitAuthor != commitCommitter {
-					c.Logf("found pr with committer different than author: %d", pr.GetNumber())
-					totalReviewed++
-				}
-			}
+// isBotCommitter is the bot-detection heuristic shared by CommitMessageHints,
+// GitAppraiseCodeReview and PhabricatorCodeReview.
+func isBotCommitter(committer string) bool {
+	for _, substring := range []string{"bot", "gardener"} {
+		if strings.Contains(committer, substring) {
+			return true
 		}
 	}
+	return false
+}
+
+// maxConcurrentPRChecks bounds how many PRs' ListReviews/GetCommit calls run at once, so a busy
+// repo with hundreds of merged PRs doesn't fan out an unbounded number of in-flight requests.
+const maxConcurrentPRChecks = 10
+
+func GithubCodeReview(c *checker.CheckRequest) checker.CheckResult {
+	// Look at some merged PRs to see if they were reviewed
+	prs, err := c.RepoClient.ListMergedPRs(c.Ctx, c.ListOptions)
+	if err != nil {
+		return checker.MakeInconclusiveResult(CheckCodeReview, err)
+	}
+
+	// A bot-committed merge only counts as reviewed if the branch protection actually requires
+	// a merge-queue bot's status check (any of mergeQueueStatusChecks; it need not belong to the
+	// bot that committed the merge) -- otherwise the bot merging proves nothing about review, and
+	// it's indistinguishable from a maintainer pushing straight to the default branch.
+	mergeQueueGated := false
+	if bp, err := c.RepoClient.GetBranchProtection(c.Ctx); err == nil {
+		mergeQueueGated = usesMergeQueueBot(bp.RequiredStatusCheckContexts)
+	}
+
+	totalMerged := len(prs)
+	totalReviewed := 0
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxConcurrentPRChecks)
+
+	for _, pr := range prs {
+		pr := pr
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if isPrReviewed(c, pr, mergeQueueGated) {
+				mu.Lock()
+				totalReviewed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
 
 	if totalReviewed > 0 {
 		c.Logf("github code reviews found")
@@ -102,36 +214,92 @@ func GithubCodeReview(c *checker.CheckRequest) checker.CheckResult {
 	return checker.MakeProportionalResult(CheckCodeReview, totalReviewed, totalMerged, .75)
 }
 
-func IsPrReviewRequired(c *checker.CheckRequest) checker.CheckResult {
-	// Look to see if review is enforced.
-	r, _, err := c.Client.Repositories.Get(c.Ctx, c.Owner, c.Repo)
+// mergeQueueBots are merge-queue accounts that land a PR as both its author and committer once
+// the project's required checks/approvals pass, so the usual "committer != author" review
+// signal never fires for them even though the merge itself attests to a review gate.
+var mergeQueueBots = []string{"bors[bot]", "mergify[bot]", "kodiak[bot]", "graphite-app[bot]"}
+
+// isPrReviewed reports whether pr was approved by a reviewer, merged by a merge-queue bot while
+// the branch protection requires some merge-queue status check (mergeQueueGated), or failing that,
+// merged by someone other than its author (a common small-PR shortcut that skips the explicit
+// approve click).
+func isPrReviewed(c *checker.CheckRequest, pr checker.PullRequest, mergeQueueGated bool) bool {
+	reviews, err := c.RepoClient.ListReviews(c.Ctx, pr.Number)
 	if err != nil {
-		return checker.MakeRetryResult(CheckCodeReview, err)
+		return false
+	}
+	for _, r := range reviews {
+		if r.State == "APPROVED" {
+			c.Logf("found review approved pr: %d", pr.Number)
+			return true
+		}
 	}
 
+	commit, err := c.RepoClient.GetCommit(c.Ctx, pr.MergeCommitSHA)
+	if err != nil {
+		return false
+	}
+	if mergeQueueGated {
+		for _, bot := range mergeQueueBots {
+			if commit.CommitterLogin == bot {
+				c.Logf("found pr merged by required merge queue bot: %d", pr.Number)
+				return true
+			}
+		}
+	}
+	if commit.AuthorLogin != "" && commit.CommitterLogin != "" && commit.AuthorLogin != commit.CommitterLogin {
+		c.Logf("found pr with committer different than author: %d", pr.Number)
+		return true
+	}
+	return false
+}
+
+// mergeQueueStatusChecks are required-status-check contexts set by merge-queue bots. A branch
+// that requires one of these to pass before merging is, in practice, also requiring some form of
+// review gate (the bot itself won't queue a PR that hasn't met the project's merge criteria).
+var mergeQueueStatusChecks = []string{"bors", "mergify", "kodiak", "graphite"}
+
+func IsPrReviewRequired(c *checker.CheckRequest) checker.CheckResult {
 	// Check the branch protection rules, we may not be able to get these though.
-	bp, _, err := c.Client.Repositories.GetBranchProtection(c.Ctx, c.Owner, c.Repo, r.GetDefaultBranch())
+	bp, err := c.RepoClient.GetBranchProtection(c.Ctx)
 	if err != nil {
 		return checker.MakeInconclusiveResult(CheckCodeReview, err)
 	}
-	if bp.GetRequiredPullRequestReviews() != nil &&
-		bp.GetRequiredPullRequestReviews().RequiredApprovingReviewCount >= 1 {
+
+	const confidence = 5
+	switch {
+	case bp.RequiredApprovingReviewCount >= 1:
 		c.Logf("pr review policy enforced")
-		const confidence = 5
-		return checker.CheckResult{
-			Name:       CheckCodeReview,
-			Pass:       true,
-			Confidence: confidence,
+	case bp.RequireCodeOwnerReviews:
+		c.Logf("codeowner review policy enforced")
+	case usesMergeQueueBot(bp.RequiredStatusCheckContexts):
+		c.Logf("merge queue bot required as a status check")
+	default:
+		// Conversation-resolution alone only forces open comment threads to be resolved; it
+		// doesn't require anyone to approve the PR, so it's not treated as a review signal here.
+		return checker.MakeInconclusiveResult(CheckCodeReview, nil)
+	}
+	return checker.CheckResult{
+		Name:       CheckCodeReview,
+		Pass:       true,
+		Confidence: confidence,
+	}
+}
+
+func usesMergeQueueBot(contexts []string) bool {
+	for _, context := range contexts {
+		for _, bot := range mergeQueueStatusChecks {
+			if strings.Contains(strings.ToLower(context), bot) {
+				return true
+			}
 		}
 	}
-	return checker.MakeInconclusiveResult(CheckCodeReview, nil)
+	return false
 }
 
 func ProwCodeReview(c *checker.CheckRequest) checker.CheckResult {
 	// Look at some merged PRs to see if they were reviewed
-	prs, _, err := c.Client.PullRequests.List(c.Ctx, c.Owner, c.Repo, &github.PullRequestListOptions{
-		State: "closed",
-	})
+	prs, err := c.RepoClient.ListMergedPRs(c.Ctx, c.ListOptions)
 	if err != nil {
 		return checker.MakeInconclusiveResult(CheckCodeReview, err)
 	}
@@ -139,12 +307,9 @@ func ProwCodeReview(c *checker.CheckRequest) checker.CheckResult {
 	totalMerged := 0
 	totalReviewed := 0
 	for _, pr := range prs {
-		if pr.MergedAt == nil {
-			continue
-		}
 		totalMerged++
 		for _, l := range pr.Labels {
-			if l.GetName() == "lgtm" || l.GetName() == "approved" {
+			if l == "lgtm" || l == "approved" {
 				totalReviewed++
 				break
 			}
@@ -158,34 +323,76 @@ func ProwCodeReview(c *checker.CheckRequest) checker.CheckResult {
 	return checker.MakeProportionalResult(CheckCodeReview, totalReviewed, totalMerged, .75)
 }
 
-func CommitMessageHints(c *checker.CheckRequest) checker.CheckResult {
-	commits, _, err := c.Client.Repositories.ListCommits(c.Ctx, c.Owner, c.Repo, &github.CommitsListOptions{})
+var (
+	differentialRevisionRegexp = regexp.MustCompile(`(?m)^Differential Revision:\s*https?://\S+/D\d+\s*$`)
+	reviewedByRegexp           = regexp.MustCompile(`(?m)^Reviewed By:\s*\S+`)
+)
+
+// PhabricatorCodeReview looks for the `Differential Revision:` and `Reviewed By:` trailers that
+// `arc diff`/`arc land` append to commit messages when a change went through
+// Phabricator/Differential review. GitHub squash-merges often drop per-commit trailers from the
+// squashed commit, so we also check the merged PR's body, where Arcanist's trailers land too.
+func PhabricatorCodeReview(c *checker.CheckRequest) checker.CheckResult {
+	commits, err := c.RepoClient.ListCommits(c.Ctx, c.ListOptions)
 	if err != nil {
 		return checker.MakeRetryResult(CheckCodeReview, err)
 	}
 
+	prs, err := c.RepoClient.ListMergedPRs(c.Ctx, c.ListOptions)
+	if err != nil {
+		return checker.MakeInconclusiveResult(CheckCodeReview, err)
+	}
+	reviewedViaPRBody := map[string]bool{}
+	for _, pr := range prs {
+		if isArcanistReviewed(pr.Body) {
+			reviewedViaPRBody[pr.MergeCommitSHA] = true
+		}
+	}
+
 	total := 0
 	totalReviewed := 0
 	for _, commit := range commits {
-		isBot := false
-		committer := commit.GetCommitter().GetLogin()
-		for _, substring := range []string{"bot", "gardener"} {
-			if strings.Contains(committer, substring) {
-				isBot = true
-				break
-			}
+		if isBotCommitter(commit.CommitterLogin) {
+			c.Logf("skip commit from bot account: %s", commit.CommitterLogin)
+			continue
+		}
+		total++
+
+		if isArcanistReviewed(commit.Message) || reviewedViaPRBody[commit.SHA] {
+			totalReviewed++
 		}
-		if isBot {
-			c.Logf("skip commit from bot account: %s", committer)
+	}
+
+	if totalReviewed == 0 {
+		return checker.MakeInconclusiveResult(CheckCodeReview, ErrorNoReviews)
+	}
+	c.Logf("phabricator code reviews found")
+	return checker.MakeProportionalResult(CheckCodeReview, totalReviewed, total, .75)
+}
+
+func isArcanistReviewed(commitMessage string) bool {
+	return differentialRevisionRegexp.MatchString(commitMessage) && reviewedByRegexp.MatchString(commitMessage)
+}
+
+func CommitMessageHints(c *checker.CheckRequest) checker.CheckResult {
+	commits, err := c.RepoClient.ListCommits(c.Ctx, c.ListOptions)
+	if err != nil {
+		return checker.MakeRetryResult(CheckCodeReview, err)
+	}
+
+	total := 0
+	totalReviewed := 0
+	for _, commit := range commits {
+		if isBotCommitter(commit.CommitterLogin) {
+			c.Logf("skip commit from bot account: %s", commit.CommitterLogin)
 			continue
 		}
 
 		total++
 
 		// check for gerrit use via Reviewed-on and Reviewed-by
-		commitMessage := commit.GetCommit().GetMessage()
-		if strings.Contains(commitMessage, "\nReviewed-on: ") &&
-			strings.Contains(commitMessage, "\nReviewed-by: ") {
+		if strings.Contains(commit.Message, "\nReviewed-on: ") &&
+			strings.Contains(commit.Message, "\nReviewed-by: ") {
 			totalReviewed++
 			continue
 		}
@@ -0,0 +1,145 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checks
+
+import "testing"
+
+func TestGitAppraiseReviewResolved(t *testing.T) {
+	tests := []struct {
+		name string
+		note string
+		want bool
+	}{
+		{
+			name: "resolved with reviewers",
+			note: `{"reviewers": ["alice"], "resolved": true}`,
+			want: true,
+		},
+		{
+			name: "not yet resolved",
+			note: `{"reviewers": ["alice"], "resolved": false}`,
+			want: false,
+		},
+		{
+			name: "resolved but no reviewers",
+			note: `{"reviewers": [], "resolved": true}`,
+			want: false,
+		},
+		{
+			name: "resolved field missing",
+			note: `{"reviewers": ["alice"]}`,
+			want: false,
+		},
+		{
+			name: "not valid json",
+			note: `not json`,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gitAppraiseReviewResolved([][]byte{[]byte(tt.note)})
+			if got != tt.want {
+				t.Errorf("gitAppraiseReviewResolved(%q) = %v, want %v", tt.note, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitAppraiseAnalysisPassed(t *testing.T) {
+	tests := []struct {
+		name string
+		note string
+		want bool
+	}{
+		{name: "passing status", note: `{"status": "pass"}`, want: true},
+		{name: "passing status different case", note: `{"status": "PASS"}`, want: true},
+		{name: "failing status", note: `{"status": "fail"}`, want: false},
+		{name: "not valid json", note: `not json`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gitAppraiseAnalysisPassed([][]byte{[]byte(tt.note)})
+			if got != tt.want {
+				t.Errorf("gitAppraiseAnalysisPassed(%q) = %v, want %v", tt.note, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsArcanistReviewed(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    bool
+	}{
+		{
+			name: "both trailers present",
+			message: "Fix the thing.\n\n" +
+				"Reviewed By: bob\n" +
+				"Differential Revision: https://phabricator.example.com/D1234\n",
+			want: true,
+		},
+		{
+			name:    "only differential revision",
+			message: "Fix the thing.\n\nDifferential Revision: https://phabricator.example.com/D1234\n",
+			want:    false,
+		},
+		{
+			name:    "only reviewed by",
+			message: "Fix the thing.\n\nReviewed By: bob\n",
+			want:    false,
+		},
+		{
+			name:    "no trailers",
+			message: "Fix the thing.\n",
+			want:    false,
+		},
+		{
+			name:    "empty message",
+			message: "",
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := isArcanistReviewed(tt.message)
+			if got != tt.want {
+				t.Errorf("isArcanistReviewed(%q) = %v, want %v", tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsesMergeQueueBot(t *testing.T) {
+	tests := []struct {
+		name     string
+		contexts []string
+		want     bool
+	}{
+		{name: "bors context", contexts: []string{"bors"}, want: true},
+		{name: "mergify context mixed case", contexts: []string{"Mergify/merge"}, want: true},
+		{name: "unrelated context", contexts: []string{"ci/travis"}, want: false},
+		{name: "no contexts", contexts: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := usesMergeQueueBot(tt.contexts)
+			if got != tt.want {
+				t.Errorf("usesMergeQueueBot(%v) = %v, want %v", tt.contexts, got, tt.want)
+			}
+		})
+	}
+}